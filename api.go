@@ -0,0 +1,220 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-co-op/gocron"
+
+	"ezan/internal/logging"
+	"ezan/internal/timings"
+)
+
+// registerAPIRoutes wires the /api/v1 control surface against the same
+// scheduler singleton and Player used by the scheduled jobs, so the CLI and
+// any UI built on top of it stay interchangeable.
+func registerAPIRoutes(router *gin.Engine) {
+	v1 := router.Group("/api/v1")
+	v1.GET("/times/today", getTimesToday)
+	v1.GET("/times/next", getTimesNext)
+	v1.POST("/adhan/play", postAdhanPlay)
+	v1.POST("/adhan/stop", postAdhanStop)
+	v1.POST("/adhan/skip", postAdhanSkip)
+	v1.POST("/adhan/snooze", postAdhanSnooze)
+	v1.GET("/status", getStatus)
+}
+
+// getTimesToday returns the five daily prayers plus sunrise, as last
+// computed by updatePrayerTimes.
+func getTimesToday(c *gin.Context) {
+	times := currentPrayerTimes()
+	c.JSON(200, gin.H{
+		"fajr":    times.Fajr,
+		"sunrise": times.Sunrise,
+		"dhuhr":   times.Dhuhr,
+		"asr":     times.Asr,
+		"maghrib": times.Maghrib,
+		"isha":    times.Isha,
+	})
+}
+
+// getTimesNext returns the next upcoming prayer and how many seconds remain
+// until it. If every prayer for today has already passed, it computes
+// tomorrow's Fajr.
+func getTimesNext(c *gin.Context) {
+	now := time.Now()
+	times := currentPrayerTimes()
+	today := []struct {
+		name string
+		time time.Time
+	}{
+		{"fajr", times.Fajr},
+		{"dhuhr", times.Dhuhr},
+		{"asr", times.Asr},
+		{"maghrib", times.Maghrib},
+		{"isha", times.Isha},
+	}
+
+	for _, p := range today {
+		if p.time.After(now) {
+			c.JSON(200, gin.H{
+				"prayer":            p.name,
+				"time":              p.time,
+				"seconds_remaining": int(time.Until(p.time).Seconds()),
+			})
+			return
+		}
+	}
+
+	coords := timings.Coordinates{Latitude: currentConfig().Lan, Longitude: currentConfig().Lon}
+	tomorrow, err := timingsProvider.Today(now.AddDate(0, 0, 1), coords)
+	if err != nil {
+		c.JSON(500, gin.H{"error": fmt.Sprintf("failed to compute tomorrow's Fajr: %v", err)})
+		return
+	}
+	c.JSON(200, gin.H{
+		"prayer":            "fajr",
+		"time":              tomorrow.Fajr,
+		"seconds_remaining": int(time.Until(tomorrow.Fajr).Seconds()),
+	})
+}
+
+// postAdhanPlay triggers on-demand playback of the named prayer's adhan.
+func postAdhanPlay(c *gin.Context) {
+	var body struct {
+		Prayer string `json:"prayer" binding:"required"`
+	}
+	if err := c.BindJSON(&body); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid JSON format"})
+		return
+	}
+
+	file, ok := audioFiles[body.Prayer]
+	if !ok {
+		c.JSON(400, gin.H{"error": fmt.Sprintf("unknown prayer %q", body.Prayer)})
+		return
+	}
+
+	go func() {
+		if err := playAudio(file, body.Prayer); err != nil {
+			logging.For(logging.Player).Error("error playing adhan on demand", "prayer", body.Prayer, "file", file, "error", err)
+		}
+	}()
+
+	c.JSON(200, gin.H{"message": fmt.Sprintf("playing %s adhan", body.Prayer)})
+}
+
+// postAdhanStop interrupts whichever adhan is currently playing, if any.
+func postAdhanStop(c *gin.Context) {
+	if stopPlayback() {
+		c.JSON(200, gin.H{"message": "playback stopped"})
+		return
+	}
+	c.JSON(200, gin.H{"message": "nothing was playing"})
+}
+
+// postAdhanSkip removes the next scheduled adhan job without playing it.
+func postAdhanSkip(c *gin.Context) {
+	next := nextAdhanJob()
+	if next == nil {
+		c.JSON(200, gin.H{"message": "no upcoming adhan to skip"})
+		return
+	}
+
+	scheduler.RemoveByReference(next)
+	c.JSON(200, gin.H{"message": fmt.Sprintf("skipped %s", prayerNameFromJob(next))})
+}
+
+// postAdhanSnooze removes the next scheduled adhan job and reschedules it
+// minutes later, one-shot.
+func postAdhanSnooze(c *gin.Context) {
+	var body struct {
+		Minutes int `json:"minutes" binding:"required"`
+	}
+	if err := c.BindJSON(&body); err != nil {
+		c.JSON(400, gin.H{"error": "Invalid JSON format"})
+		return
+	}
+
+	next := nextAdhanJob()
+	if next == nil {
+		c.JSON(200, gin.H{"message": "no upcoming adhan to snooze"})
+		return
+	}
+
+	prayerName := prayerNameFromJob(next)
+	newTime := next.NextRun().Add(time.Duration(body.Minutes) * time.Minute)
+
+	scheduler.RemoveByReference(next)
+	scheduleAdhan(scheduler, prayerName, newTime)
+
+	c.JSON(200, gin.H{"message": fmt.Sprintf("snoozed %s to %s", prayerName, newTime.Format(time.RFC3339))})
+}
+
+// getStatus reports the scheduler's job list, the active config, and
+// whether the audio backend is healthy.
+func getStatus(c *gin.Context) {
+	jobs := make([]gin.H, 0, len(scheduler.Jobs()))
+	for _, j := range scheduler.Jobs() {
+		jobs = append(jobs, gin.H{
+			"tags":     j.Tags(),
+			"next_run": j.NextRun(),
+		})
+	}
+
+	health := "ok"
+	if err := currentPlayer().Test(); err != nil {
+		health = err.Error()
+	}
+
+	cfg := currentConfig()
+	c.JSON(200, gin.H{
+		"jobs":   jobs,
+		"config": cfg,
+		"backend": gin.H{
+			"audio_backend": cfg.AudioBackend,
+			"health":        health,
+		},
+	})
+}
+
+// nextAdhanJob returns the soonest-running job tagged "adhan", or nil if
+// none are scheduled.
+func nextAdhanJob() *gocron.Job {
+	var next *gocron.Job
+	for _, j := range scheduler.Jobs() {
+		if !hasTag(j.Tags(), "adhan") {
+			continue
+		}
+		if next == nil || j.NextRun().Before(next.NextRun()) {
+			next = j
+		}
+	}
+	return next
+}
+
+// prayerNameFromJob returns the prayer name tag on an adhan job, skipping
+// the generic "adhan"/"sela" tags and the per-day "adhan-YYYYMMDD" tag that
+// scheduleAdhan/scheduleSela also set.
+func prayerNameFromJob(j *gocron.Job) string {
+	for _, t := range j.Tags() {
+		if t == "adhan" || t == "sela" {
+			continue
+		}
+		if _, _, ok := splitDateTag(t); ok {
+			continue
+		}
+		return t
+	}
+	return "unknown"
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
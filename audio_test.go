@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAudioFileForPrayer(t *testing.T) {
+	friday := time.Date(2025, 1, 17, 12, 0, 0, 0, time.UTC) // a Friday
+	saturday := time.Date(2025, 1, 18, 12, 0, 0, 0, time.UTC)
+
+	configMu.Lock()
+	config = Config{}
+	config.Jumuah.Enabled = true
+	config.Jumuah.AudioFile = "audio/custom-jumuah.mp3"
+	configMu.Unlock()
+
+	cases := []struct {
+		name       string
+		prayerName string
+		prayerTime time.Time
+		want       string
+	}{
+		{"dhuhr on Friday with Jumu'ah override", "dhuhr", friday, "audio/custom-jumuah.mp3"},
+		{"dhuhr on Saturday uses the regular file", "dhuhr", saturday, audioFiles["dhuhr"]},
+		{"fajr is never substituted", "fajr", friday, audioFiles["fajr"]},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := audioFileForPrayer(tc.prayerName, tc.prayerTime); got != tc.want {
+				t.Errorf("audioFileForPrayer(%q, %v) = %q, want %q", tc.prayerName, tc.prayerTime, got, tc.want)
+			}
+		})
+	}
+
+	configMu.Lock()
+	config.Jumuah.AudioFile = ""
+	configMu.Unlock()
+
+	if got := audioFileForPrayer("dhuhr", friday); got != "audio/jumuah.mp3" {
+		t.Errorf("audioFileForPrayer with no Jumuah.AudioFile override = %q, want %q", got, "audio/jumuah.mp3")
+	}
+}
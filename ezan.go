@@ -1,21 +1,26 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/BurntSushi/toml"
-	"github.com/faiface/beep"
-	"github.com/faiface/beep/effects"
-	"github.com/faiface/beep/mp3"
-	"github.com/faiface/beep/speaker"
 	calc "github.com/furkan000/adhango/pkg/calc"
-	data "github.com/furkan000/adhango/pkg/data"
 	util "github.com/furkan000/adhango/pkg/util"
 	"github.com/gin-gonic/gin"
 	"github.com/go-co-op/gocron"
+
+	"ezan/internal/logging"
+	"ezan/internal/player"
+	"ezan/internal/timings"
 )
 
 type Config struct {
@@ -23,6 +28,11 @@ type Config struct {
 	Lon               float64 `toml:"lon"`
 	CalculationMethod string  `toml:"calculation_method"`
 	AdhanPrayer       bool    `toml:"adhan_prayer"`
+	AudioBackend      string  `toml:"audio_backend"`
+	Provider          string  `toml:"provider"`
+	AladhanBaseURL    string  `toml:"aladhan_base_url"`
+	LogFormat         string  `toml:"log_format"`
+	ScheduleDays      int     `toml:"schedule_days"`
 	Volume            struct {
 		Fajr        float64 `toml:"fajr"`
 		Dhuhr       float64 `toml:"dhur"`
@@ -32,22 +42,169 @@ type Config struct {
 		AdhanPrayer float64 `toml:"adhan_prayer"`
 		Sela        float64 `toml:"sela"`
 	} `toml:"volume"`
+	Sela struct {
+		Enabled       bool `toml:"enabled"`
+		OffsetMinutes int  `toml:"offset_minutes"`
+		Fajr          bool `toml:"fajr"`
+		Dhuhr         bool `toml:"dhuhr"`
+		Asr           bool `toml:"asr"`
+		Maghrib       bool `toml:"maghrib"`
+		Isha          bool `toml:"isha"`
+	} `toml:"sela"`
+	Jumuah struct {
+		Enabled           bool   `toml:"enabled"`
+		AudioFile         string `toml:"audio_file"`
+		SelaOffsetMinutes int    `toml:"sela_offset_minutes"`
+	} `toml:"jumuah"`
 }
 
 var (
-	scheduler   = gocron.NewScheduler(time.Local)
-	config      Config
-	madhab      = calc.SHAFI_HANBALI_MALIKI
-	coordinates *util.Coordinates
+	scheduler       = gocron.NewScheduler(time.Local)
+	madhab          = calc.SHAFI_HANBALI_MALIKI
+	coordinates     *util.Coordinates
+	timingsProvider timings.Provider
+
+	// config is reloaded by onUpdateSettings on a different goroutine than
+	// the HTTP handlers and the scheduler that read it, so access goes
+	// through configMu rather than the bare variable.
+	config   Config
+	configMu sync.RWMutex
+
+	// activePlayer is swapped out by initPlayer on a settings reload, so
+	// reads/writes go through playerMu rather than the bare variable.
+	activePlayer player.Player
+	playerMu     sync.RWMutex
+
+	// lastPrayerTimes holds the most recently computed times, serving the
+	// /api/v1/times/* endpoints without recomputing them. It's written by
+	// updatePrayerTimes (the scheduler goroutine) and read by the HTTP
+	// handlers, so access goes through lastPrayerTimesMu.
+	lastPrayerTimes   timings.Times
+	lastPrayerTimesMu sync.RWMutex
+
+	// playSerialize ensures only one playAudio call is in flight at a time,
+	// so cancelPlayback always refers to that single call.
+	playSerialize sync.Mutex
+
+	// playbackMu guards cancelPlayback, the cancel func for whichever
+	// playAudio call is currently in flight (nil if none is).
+	playbackMu     sync.Mutex
+	cancelPlayback context.CancelFunc
 )
 
+// currentPlayer returns the active audio backend, synchronized against
+// initPlayer swapping it out during a settings reload.
+func currentPlayer() player.Player {
+	playerMu.RLock()
+	defer playerMu.RUnlock()
+	return activePlayer
+}
+
+// currentConfig returns the active config, synchronized against
+// onUpdateSettings reloading it from another goroutine.
+func currentConfig() Config {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return config
+}
+
+// currentPrayerTimes returns the most recently computed prayer times,
+// synchronized against updatePrayerTimes recomputing them from another
+// goroutine.
+func currentPrayerTimes() timings.Times {
+	lastPrayerTimesMu.RLock()
+	defer lastPrayerTimesMu.RUnlock()
+	return lastPrayerTimes
+}
+
 func loadConfig() error {
-	if _, err := toml.DecodeFile("config.toml", &config); err != nil {
+	var next Config
+	if _, err := toml.DecodeFile("config.toml", &next); err != nil {
 		return fmt.Errorf("error loading config: %v", err)
 	}
+	configMu.Lock()
+	config = next
+	configMu.Unlock()
 	return nil
 }
 
+// initPlayer (re)builds activePlayer from config.AudioBackend, cleanly
+// stopping whichever backend was previously active.
+func initPlayer() error {
+	p, err := player.New(currentConfig().AudioBackend)
+	if err != nil {
+		return fmt.Errorf("error initializing audio backend: %v", err)
+	}
+
+	playerMu.Lock()
+	previous := activePlayer
+	activePlayer = p
+	playerMu.Unlock()
+
+	if previous != nil {
+		if err := previous.Stop(); err != nil {
+			logging.For(logging.Player).Warn("error stopping previous audio backend", "error", err)
+		}
+	}
+	return nil
+}
+
+// initTimingsProvider (re)builds timingsProvider from config.Provider: the
+// local adhango-based calculation, or aladhan.com with local calculation as
+// its offline fallback.
+func initTimingsProvider() {
+	cfg := currentConfig()
+
+	school := 0 // Shafi, Hanbali, Maliki
+	if madhab == calc.HANAFI {
+		school = 1
+	}
+	local := timings.NewLocalProvider(getCalculationMethod(cfg.CalculationMethod), madhab)
+
+	switch cfg.Provider {
+	case "", "local":
+		timingsProvider = local
+	case "aladhan":
+		timingsProvider = timings.NewAladhanProvider(cfg.AladhanBaseURL, aladhanMethodID(cfg.CalculationMethod), school, "cache", local)
+	default:
+		logging.For(logging.Timings).Warn("unknown provider, defaulting to local calculation", "provider", cfg.Provider)
+		timingsProvider = local
+	}
+}
+
+// aladhanMethodID maps our calculation_method config strings to aladhan.com's
+// numeric method ids.
+func aladhanMethodID(methodStr string) int {
+	switch methodStr {
+	case "KARACHI":
+		return 1
+	case "NORTH_AMERICA":
+		return 2
+	case "MUSLIM_WORLD_LEAGUE":
+		return 3
+	case "UMM_AL_QURA":
+		return 4
+	case "EGYPTIAN":
+		return 5
+	case "KUWAIT":
+		return 9
+	case "QATAR":
+		return 10
+	case "SINGAPORE":
+		return 11
+	case "UOIF":
+		return 12
+	case "TURKEY":
+		return 13
+	case "MOON_SIGHTING_COMMITTEE":
+		return 15
+	case "DUBAI":
+		return 16
+	default:
+		return 99 // Custom/other
+	}
+}
+
 // audioFiles maps prayer names to their audio file paths.
 var audioFiles = map[string]string{
 	"fajr":    "audio/ezan1.mp3",
@@ -62,155 +219,317 @@ var audioFiles = map[string]string{
 
 // getVolumeForPrayer returns the volume for a specific prayer type
 func getVolumeForPrayer(prayerType string) float64 {
+	volume := currentConfig().Volume
 	switch prayerType {
 	case "fajr":
-		return config.Volume.Fajr
+		return volume.Fajr
 	case "dhuhr":
-		return config.Volume.Dhuhr
+		return volume.Dhuhr
 	case "asr":
-		return config.Volume.Asr
+		return volume.Asr
 	case "maghrib":
-		return config.Volume.Maghrib
+		return volume.Maghrib
 	case "isha":
-		return config.Volume.Isha
+		return volume.Isha
 	case "prayer":
-		return config.Volume.AdhanPrayer
+		return volume.AdhanPrayer
 	case "sela":
-		return config.Volume.Sela
+		return volume.Sela
 	default:
 		return 100 // Default volume for test and unknown types
 	}
 }
 
-// playAudio plays the specified MP3 file with volume adjustment.
+// playAudio plays the specified MP3 file through the configured audio
+// backend, with the volume for the given audio type. The playback can be
+// interrupted by stopPlayback while it's in progress.
+//
+// Calls are serialized on playSerialize so cancelPlayback only ever refers to
+// this one in-flight call: a scheduled adhan/sela job and an on-demand
+// /api/v1/adhan/play request racing here queue up rather than both touching
+// the single shared cancelPlayback slot.
 func playAudio(filepath string, audioType string) error {
-	f, err := os.Open(filepath)
-	if err != nil {
-		return fmt.Errorf("error opening audio file: %v", err)
-	}
-	defer f.Close()
+	playSerialize.Lock()
+	defer playSerialize.Unlock()
 
-	streamer, format, err := mp3.Decode(f)
-	if err != nil {
-		return fmt.Errorf("error decoding MP3: %v", err)
-	}
-	defer streamer.Close()
+	ctx, cancel := context.WithCancel(context.Background())
 
-	err = speaker.Init(format.SampleRate, format.SampleRate.N(time.Second/10))
-	if err != nil {
-		return fmt.Errorf("error initializing speaker: %v", err)
-	}
+	playbackMu.Lock()
+	cancelPlayback = cancel
+	playbackMu.Unlock()
 
-	// Calculate volume adjustment
-	// Convert percentage to logarithmic scale where:
-	// 0% = silence (very low volume, -4 is approximately -96dB)
-	// 100% = normal volume (0 dB, no change)
-	volume := getVolumeForPrayer(audioType)
-	volumeAdjusted := &effects.Volume{
-		Streamer: streamer,
-		Base:     2,
-		Volume:   -4 + (volume / 100.0 * 4), // Scale from -4 to 0
-	}
+	defer func() {
+		playbackMu.Lock()
+		cancelPlayback = nil
+		playbackMu.Unlock()
+		cancel()
+	}()
 
-	done := make(chan bool)
-	speaker.Play(beep.Seq(volumeAdjusted, beep.Callback(func() {
-		done <- true
-	})))
+	return currentPlayer().Play(ctx, filepath, getVolumeForPrayer(audioType))
+}
 
-	<-done
-	return nil
+// stopPlayback interrupts the in-flight playAudio call, if any, and reports
+// whether there was one to interrupt.
+func stopPlayback() bool {
+	playbackMu.Lock()
+	cancel := cancelPlayback
+	playbackMu.Unlock()
+
+	if cancel == nil {
+		return false
+	}
+	cancel()
+	return true
 }
 
 // testAudioOutput tests the audio system by playing the Fajr adhan.
 func testAudioOutput() {
-	fmt.Println("🔊 Testing audio output...")
+	logger := logging.For(logging.Player)
+	logger.Info("testing audio output")
 	err := playAudio(audioFiles["fajr"], "fajr")
 	if err != nil {
-		log.Printf("❌ Audio test failed: %v\n", err)
+		logger.Error("audio test failed", "error", err)
 	} else {
-		fmt.Println("✅ Audio test successful! Test played.")
+		logger.Info("audio test successful")
 	}
 }
 
 // scheduleAdhan schedules the adhan playback for a specific prayer.
 // The job is tagged "adhan" so that we can later remove only these jobs.
 func scheduleAdhan(scheduler *gocron.Scheduler, prayerName string, prayerTime time.Time) {
-	fmt.Printf("🕰️ Scheduling %s Adhan at %v\n", prayerName, prayerTime)
-	// Use seconds precision in the formatted time.
+	logger := logging.For(logging.Scheduler)
+	logger.Debug("scheduling adhan", "prayer", prayerName, "scheduled_at", prayerTime)
+	// StartAt pins the job to this exact date and time, rather than the next
+	// occurrence of its wall-clock time, so distinct future days don't collide.
 	scheduler.Every(1).Day().LimitRunsTo(1).
-		At(prayerTime.Format("15:04:05")).
-		Tag("adhan"). // Tag the job for later removal.
+		StartAt(prayerTime).
+		Tag("adhan", dateTag("adhan", prayerTime), prayerName). // Tag for lookup and per-day removal.
 		Do(func() {
-			fmt.Printf("📢 Playing %s Adhan at %v\n", prayerName, prayerTime)
-			err := playAudio(audioFiles[prayerName], prayerName)
+			file := audioFileForPrayer(prayerName, prayerTime)
+			logger.Info("playing adhan", "prayer", prayerName, "scheduled_at", prayerTime, "file", file, "volume_db", getVolumeForPrayer(prayerName))
+			err := playAudio(file, prayerName)
 			if err != nil {
-				log.Printf("❌ Error playing %s Adhan: %v\n", prayerName, err)
+				logger.Error("error playing adhan", "prayer", prayerName, "file", file, "error", err)
 				return
 			}
 
 			// Play prayer after adhan only for the five daily prayers if enabled
-			if prayerName != "test" && config.AdhanPrayer {
-				fmt.Printf("🤲 Playing prayer after %s Adhan\n", prayerName)
+			if prayerName != "test" && currentConfig().AdhanPrayer {
+				logger.Info("playing prayer after adhan", "prayer", prayerName, "file", audioFiles["prayer"], "volume_db", getVolumeForPrayer("prayer"))
 				err = playAudio(audioFiles["prayer"], "prayer")
 				if err != nil {
-					log.Printf("❌ Error playing prayer after %s Adhan: %v\n", prayerName, err)
+					logger.Error("error playing prayer after adhan", "prayer", prayerName, "error", err)
 				}
 			}
 		})
 }
 
-// updatePrayerTimes calculates and schedules prayer times for the current day.
-func updatePrayerTimes(scheduler *gocron.Scheduler) {
-	// Use global coordinates
+// audioFileForPrayer returns the audio file to play for a prayer at the
+// given time, substituting the Jumu'ah override for Dhuhr on Fridays when
+// configured.
+func audioFileForPrayer(prayerName string, prayerTime time.Time) string {
+	jumuah := currentConfig().Jumuah
+	if prayerName == "dhuhr" && jumuah.Enabled && prayerTime.Weekday() == time.Friday {
+		if jumuah.AudioFile != "" {
+			return jumuah.AudioFile
+		}
+		return "audio/jumuah.mp3"
+	}
+	return audioFiles[prayerName]
+}
+
+// scheduleSela schedules a Sela reminder offsetMinutes before prayerTime,
+// tagged "sela" so it can be removed alongside the adhan jobs on reload.
+func scheduleSela(scheduler *gocron.Scheduler, prayerName string, prayerTime time.Time, offsetMinutes int) {
+	logger := logging.For(logging.Scheduler)
+	selaTime := prayerTime.Add(-time.Duration(offsetMinutes) * time.Minute)
+	logger.Debug("scheduling sela", "prayer", prayerName, "scheduled_at", selaTime)
+	scheduler.Every(1).Day().LimitRunsTo(1).
+		StartAt(selaTime).
+		Tag("sela", dateTag("sela", prayerTime), prayerName).
+		Do(func() {
+			logger.Info("playing sela", "prayer", prayerName, "scheduled_at", selaTime, "file", audioFiles["sela"], "volume_db", getVolumeForPrayer("sela"))
+			if err := playAudio(audioFiles["sela"], "sela"); err != nil {
+				logger.Error("error playing sela", "prayer", prayerName, "error", err)
+			}
+		})
+}
 
-	// Get current date.
-	currentDate := time.Now()
-	date := data.NewDateComponents(currentDate)
+// selaOffsetMinutes returns config.Sela.OffsetMinutes, defaulting to 10 when
+// unset.
+func selaOffsetMinutes() int {
+	if minutes := currentConfig().Sela.OffsetMinutes; minutes > 0 {
+		return minutes
+	}
+	return 10
+}
 
-	method := getCalculationMethod(config.CalculationMethod)
+// jumuahSelaOffsetMinutes returns config.Jumuah.SelaOffsetMinutes, defaulting
+// to 10 when unset.
+func jumuahSelaOffsetMinutes() int {
+	if minutes := currentConfig().Jumuah.SelaOffsetMinutes; minutes > 0 {
+		return minutes
+	}
+	return 10
+}
 
-	// Configure calculation parameters using builder.
-	params := calc.NewCalculationParametersBuilder().
-		SetMadhab(madhab).
-		SetMethod(method).
-		Build()
+// dateTagLayout is the YYYYMMDD suffix used by dateTag.
+const dateTagLayout = "20060102"
 
-	// Calculate prayer times.
-	prayerTimes, err := calc.NewPrayerTimes(coordinates, date, params)
-	if err != nil {
-		log.Printf("Error calculating prayer times: %v", err)
-		return
+// dateTag returns the per-day tag grouping a prefix's jobs for date, e.g.
+// dateTag("adhan", t) -> "adhan-20250115".
+func dateTag(prefix string, date time.Time) string {
+	return prefix + "-" + date.Format(dateTagLayout)
+}
+
+// splitDateTag splits a "prefix-YYYYMMDD" tag, reporting ok=false for tags
+// that aren't in that shape.
+func splitDateTag(tag string) (prefix, datePart string, ok bool) {
+	parts := strings.SplitN(tag, "-", 2)
+	if len(parts) != 2 || len(parts[1]) != len(dateTagLayout) {
+		return "", "", false
 	}
+	return parts[0], parts[1], true
+}
 
-	// Set timezone to local.
-	err = prayerTimes.SetTimeZone(currentDate.Location().String())
-	if err != nil {
-		log.Printf("Error setting timezone: %v", err)
-		return
+// removeStaleDateTags removes adhan/sela jobs dated before today, leaving
+// jobs for today and already-scheduled future days untouched.
+func removeStaleDateTags(scheduler *gocron.Scheduler, today time.Time) {
+	todayTag := today.Format(dateTagLayout)
+	for _, j := range scheduler.Jobs() {
+		for _, t := range j.Tags() {
+			prefix, datePart, ok := splitDateTag(t)
+			if !ok || (prefix != "adhan" && prefix != "sela") {
+				continue
+			}
+			if datePart < todayTag {
+				scheduler.RemoveByReference(j)
+			}
+			break
+		}
+	}
+}
+
+// hasDateTaggedJob reports whether any scheduled job carries the given tag.
+func hasDateTaggedJob(scheduler *gocron.Scheduler, tag string) bool {
+	for _, j := range scheduler.Jobs() {
+		if hasTag(j.Tags(), tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeSchedulePlan persists the current rolling schedule to
+// cache/schedule.txt (one line per prayer, e.g.
+// "2025-01-15 05:32:00 fajr audio/ezan1.mp3") so the queued plan survives a
+// crash and can be inspected without the scheduler running.
+func writeSchedulePlan(lines []string) error {
+	if err := os.MkdirAll("cache", 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join("cache", "schedule.txt"), []byte(strings.Join(lines, "\n")+"\n"), 0o644)
+}
+
+// updatePrayerTimes computes and schedules prayer times for a rolling window
+// of config.ScheduleDays days (default 7) starting today. Today is always
+// recomputed and rescheduled from scratch, since config may have changed;
+// future days already scheduled by an earlier refresh are left alone, and
+// only stale (past) per-day tags are cleaned up, so a crash and restart
+// doesn't lose the days already queued ahead. Any of today's prayers whose
+// time has already passed are logged as missed rather than replayed late.
+func updatePrayerTimes(scheduler *gocron.Scheduler) {
+	cfg := currentConfig()
+	days := cfg.ScheduleDays
+	if days <= 0 {
+		days = 7
 	}
 
-	fmt.Println("📅 Today's Prayer Times:")
-	fmt.Printf("🌅 Fajr: %v\n", prayerTimes.Fajr)
-	fmt.Printf("☀️ Dhuhr: %v\n", prayerTimes.Dhuhr)
-	fmt.Printf("🏙️ Asr: %v\n", prayerTimes.Asr)
-	fmt.Printf("🌇 Maghrib: %v\n", prayerTimes.Maghrib)
-	fmt.Printf("🌙 Isha: %v\n", prayerTimes.Isha)
+	now := time.Now()
+	coords := timings.Coordinates{Latitude: cfg.Lan, Longitude: cfg.Lon}
+	timingsLogger := logging.For(logging.Timings)
+	schedulerLogger := logging.For(logging.Scheduler)
+
+	// Today's own jobs are rebuilt every refresh, so clear them before
+	// recomputing; stale days from before today are cleared too.
+	scheduler.RemoveByTag(dateTag("adhan", now))
+	scheduler.RemoveByTag(dateTag("sela", now))
+	removeStaleDateTags(scheduler, now)
+
+	var plan []string
+	for offset := 0; offset < days; offset++ {
+		date := now.AddDate(0, 0, offset)
+
+		prayerTimes, err := timingsProvider.Today(date, coords)
+		if err != nil {
+			timingsLogger.Error("error fetching prayer times", "date", date.Format("2006-01-02"), "error", err)
+			continue
+		}
+
+		if offset == 0 {
+			lastPrayerTimesMu.Lock()
+			lastPrayerTimes = prayerTimes
+			lastPrayerTimesMu.Unlock()
+			timingsLogger.Info("today's prayer times",
+				"fajr", prayerTimes.Fajr,
+				"dhuhr", prayerTimes.Dhuhr,
+				"asr", prayerTimes.Asr,
+				"maghrib", prayerTimes.Maghrib,
+				"isha", prayerTimes.Isha,
+			)
+		}
+
+		// Days beyond today were already scheduled by an earlier refresh;
+		// still include them in the plan, but don't add duplicate jobs.
+		alreadyScheduled := offset > 0 && hasDateTaggedJob(scheduler, dateTag("adhan", date))
+
+		prayers := []struct {
+			name        string
+			time        time.Time
+			selaEnabled bool
+		}{
+			{"fajr", prayerTimes.Fajr, cfg.Sela.Fajr},
+			{"dhuhr", prayerTimes.Dhuhr, cfg.Sela.Dhuhr},
+			{"asr", prayerTimes.Asr, cfg.Sela.Asr},
+			{"maghrib", prayerTimes.Maghrib, cfg.Sela.Maghrib},
+			{"isha", prayerTimes.Isha, cfg.Sela.Isha},
+		}
+
+		isJumuah := cfg.Jumuah.Enabled && date.Weekday() == time.Friday
 
-	// Remove only the prayer time jobs (tagged "adhan").
-	scheduler.RemoveByTag("adhan")
+		for _, p := range prayers {
+			if p.time.Before(now) {
+				schedulerLogger.Warn("missed adhan, prayer time already passed", "prayer", p.name, "scheduled_at", p.time)
+				continue
+			}
 
-	// Schedule each prayer time.
-	scheduleAdhan(scheduler, "fajr", prayerTimes.Fajr)
-	scheduleAdhan(scheduler, "dhuhr", prayerTimes.Dhuhr)
-	scheduleAdhan(scheduler, "asr", prayerTimes.Asr)
-	scheduleAdhan(scheduler, "maghrib", prayerTimes.Maghrib)
-	scheduleAdhan(scheduler, "isha", prayerTimes.Isha)
+			plan = append(plan, fmt.Sprintf("%s %s %s", p.time.Format("2006-01-02 15:04:05"), p.name, audioFileForPrayer(p.name, p.time)))
+
+			if alreadyScheduled {
+				continue
+			}
+
+			scheduleAdhan(scheduler, p.name, p.time)
+
+			switch {
+			case p.name == "dhuhr" && isJumuah:
+				// The Jumu'ah pre-khutbah Sela replaces the ordinary Dhuhr Sela on Fridays.
+				scheduleSela(scheduler, p.name, p.time, jumuahSelaOffsetMinutes())
+			case cfg.Sela.Enabled && p.selaEnabled:
+				scheduleSela(scheduler, p.name, p.time, selaOffsetMinutes())
+			}
+		}
+	}
+
+	if err := writeSchedulePlan(plan); err != nil {
+		timingsLogger.Warn("failed to write schedule plan", "error", err)
+	}
 }
 
 func testThreeSecondsFromNow(scheduler *gocron.Scheduler) {
 	// Schedule a test job 3 seconds from now.
 	t := time.Now().Add(3 * time.Second)
-	fmt.Println("Scheduled time:", t)
+	logging.For(logging.Scheduler).Debug("scheduling test job", "scheduled_at", t)
 	scheduleAdhan(scheduler, "fajr", t)
 }
 
@@ -223,13 +542,13 @@ func updateSettingsHandler(c *gin.Context) {
 	}
 
 	// Read current config
-	var currentConfig map[string]interface{}
+	var fileConfig map[string]interface{}
 	configBytes, err := os.ReadFile("config.toml")
 	if err != nil {
 		c.JSON(500, gin.H{"error": "Failed to read config file"})
 		return
 	}
-	if _, err := toml.Decode(string(configBytes), &currentConfig); err != nil {
+	if _, err := toml.Decode(string(configBytes), &fileConfig); err != nil {
 		c.JSON(500, gin.H{"error": "Failed to parse config file"})
 		return
 	}
@@ -239,14 +558,14 @@ func updateSettingsHandler(c *gin.Context) {
 		if key == "volume" {
 			// Handle volume updates separately as it's a nested structure
 			if volumeUpdates, ok := value.(map[string]interface{}); ok {
-				if currentVolume, ok := currentConfig["volume"].(map[string]interface{}); ok {
+				if currentVolume, ok := fileConfig["volume"].(map[string]interface{}); ok {
 					for k, v := range volumeUpdates {
 						currentVolume[k] = v
 					}
 				}
 			}
 		} else {
-			currentConfig[key] = value
+			fileConfig[key] = value
 		}
 	}
 
@@ -259,7 +578,7 @@ func updateSettingsHandler(c *gin.Context) {
 	defer f.Close()
 
 	encoder := toml.NewEncoder(f)
-	if err := encoder.Encode(currentConfig); err != nil {
+	if err := encoder.Encode(fileConfig); err != nil {
 		c.JSON(500, gin.H{"error": "Failed to write config file"})
 		return
 	}
@@ -276,24 +595,47 @@ func main() {
 	if err = loadConfig(); err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	logging.SetFormat(currentConfig().LogFormat)
+
+	// Initialize the audio backend.
+	if err := initPlayer(); err != nil {
+		log.Fatalf("Failed to initialize audio backend: %v", err)
+	}
 
 	// Setup Gin router
 	router := gin.Default()
 	router.POST("/settings", updateSettingsHandler)
+	registerAPIRoutes(router)
 
 	// Start HTTP server in a goroutine
 	go func() {
 		if err := router.Run(":8080"); err != nil {
-			log.Printf("Failed to start HTTP server: %v", err)
+			logging.For(logging.HTTP).Error("failed to start HTTP server", "error", err)
 		}
 	}()
 
 	// Initialize coordinates
-	coordinates, err = util.NewCoordinates(config.Lan, config.Lon)
+	cfg := currentConfig()
+	coordinates, err = util.NewCoordinates(cfg.Lan, cfg.Lon)
 	if err != nil {
 		log.Fatalf("Failed to initialize coordinates: %v", err)
 	}
 
+	initTimingsProvider()
+
+	// Cleanly terminate any audio backend child processes on shutdown.
+	shutdown := make(chan os.Signal, 1)
+	signal.Notify(shutdown, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-shutdown
+		logger := logging.For(logging.Player)
+		logger.Info("shutting down")
+		if err := currentPlayer().Stop(); err != nil {
+			logger.Warn("error stopping audio backend", "error", err)
+		}
+		os.Exit(0)
+	}()
+
 	// Schedule daily update at midnight.
 	scheduler.Every(1).Day().At("00:00").Do(func() {
 		updatePrayerTimes(scheduler)
@@ -340,27 +682,40 @@ func getCalculationMethod(methodStr string) calc.CalculationMethod {
 	case "UOIF":
 		return calc.UOIF
 	default:
-		log.Printf("Unknown calculation method %s, defaulting to TURKEY", methodStr)
+		logging.For(logging.Timings).Warn("unknown calculation method, defaulting to TURKEY", "method", methodStr)
 		return calc.TURKEY
 	}
 }
 
 func onUpdateSettings() {
+	logger := logging.For(logging.Config)
+
 	// Reload config file
 	if err := loadConfig(); err != nil {
-		log.Printf("Failed to reload config: %v", err)
+		logger.Error("failed to reload config", "error", err)
 		return
 	}
+	cfg := currentConfig()
+	logging.SetFormat(cfg.LogFormat)
 
 	// Update coordinates if lat/lon changed
 	var err error
-	coordinates, err = util.NewCoordinates(config.Lan, config.Lon)
+	coordinates, err = util.NewCoordinates(cfg.Lan, cfg.Lon)
 	if err != nil {
-		log.Printf("Failed to update coordinates: %v", err)
+		logger.Error("failed to update coordinates", "error", err)
 		return
 	}
 
+	// Re-initialize the audio backend in case audio_backend changed,
+	// cleanly terminating any previous backend's child processes.
+	if err := initPlayer(); err != nil {
+		logger.Error("failed to re-initialize audio backend", "error", err)
+		return
+	}
+
+	initTimingsProvider()
+
 	// Remove existing jobs and reschedule with new settings
-	scheduler.RemoveByTag("adhan")
+	scheduler.RemoveByTagsAny("adhan", "sela")
 	updatePrayerTimes(scheduler)
 }
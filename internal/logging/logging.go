@@ -0,0 +1,103 @@
+// Package logging provides per-component slog.Logger instances so that log
+// output from scheduling, audio playback, HTTP, config reload, and prayer
+// time lookups can be filtered and formatted independently.
+package logging
+
+import (
+	"log/slog"
+	"os"
+	"path"
+	"strings"
+	"sync"
+)
+
+// Component names used to scope loggers. These also double as the values
+// matched against the DEBUG env var.
+const (
+	Scheduler = "scheduler"
+	Player    = "player"
+	HTTP      = "http"
+	Config    = "config"
+	Timings   = "timings"
+)
+
+var (
+	mu      sync.Mutex
+	format  = "text"
+	loggers = map[string]*slog.Logger{}
+)
+
+// SetFormat selects the log output format, "text" or "json"; anything else
+// falls back to "text". Call it once during startup, before the first For
+// call, so every component logger picks it up.
+func SetFormat(f string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if f != "json" {
+		f = "text"
+	}
+	format = f
+	loggers = map[string]*slog.Logger{}
+}
+
+// For returns the logger scoped to component, tagging every record with a
+// "component" attribute. Debug level is enabled per-component via the DEBUG
+// env var: a bare name matches that component exactly (DEBUG="scheduler"),
+// and a "name.*" pattern matches that component or any dotted
+// sub-component of it (DEBUG="player.*,scheduler" enables debug logging for
+// the player component and, exactly, the scheduler component) — today's
+// component names are all flat, but this keeps working if they ever grow
+// sub-components like "player.mpv".
+func For(component string) *slog.Logger {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if l, ok := loggers[component]; ok {
+		return l
+	}
+
+	level := slog.LevelInfo
+	if debugEnabled(component) {
+		level = slog.LevelDebug
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	if format == "json" {
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	} else {
+		handler = slog.NewTextHandler(os.Stdout, opts)
+	}
+
+	l := slog.New(handler).With("component", component)
+	loggers[component] = l
+	return l
+}
+
+// debugEnabled reports whether component matches one of the comma-separated
+// patterns in the DEBUG env var: an exact component name, a "name.*" prefix
+// (matching that component or any dotted sub-component of it — path.Match
+// alone won't do this, since "name.*" requires a literal "name." prefix that
+// a bare "name" component doesn't have), or any other glob pattern.
+func debugEnabled(component string) bool {
+	debug := os.Getenv("DEBUG")
+	if debug == "" {
+		return false
+	}
+	for _, pattern := range strings.Split(debug, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if pattern == component {
+			return true
+		}
+		if base, ok := strings.CutSuffix(pattern, ".*"); ok && base == component {
+			return true
+		}
+		if matched, _ := path.Match(pattern, component); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package logging
+
+import "testing"
+
+func TestDebugEnabled(t *testing.T) {
+	cases := []struct {
+		name      string
+		debug     string
+		component string
+		want      bool
+	}{
+		{"exact match", "scheduler", "scheduler", true},
+		{"exact no match", "scheduler", "player", false},
+		{"dotted-prefix pattern matches bare component", "player.*", "player", true},
+		{"dotted-prefix pattern matches sub-component via glob", "player.*", "player.mpv", true},
+		{"generic glob pattern", "htt?", "http", true},
+		{"one of several comma-separated patterns", "scheduler,player.*,config", "player", true},
+		{"empty DEBUG disables everything", "", "player", false},
+		{"unrelated pattern does not match", "scheduler", "timings", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("DEBUG", tc.debug)
+			if got := debugEnabled(tc.component); got != tc.want {
+				t.Errorf("debugEnabled(%q) with DEBUG=%q = %v, want %v", tc.component, tc.debug, got, tc.want)
+			}
+		})
+	}
+}
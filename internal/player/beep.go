@@ -0,0 +1,89 @@
+package player
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/faiface/beep"
+	"github.com/faiface/beep/effects"
+	"github.com/faiface/beep/mp3"
+	"github.com/faiface/beep/speaker"
+)
+
+// BeepPlayer plays audio through faiface/beep, the original in-process
+// backend. speaker.Init is only called once (or again if the sample rate of
+// an incoming file changes) so repeated plays don't re-open the output
+// device.
+type BeepPlayer struct {
+	mu          sync.Mutex
+	initialized bool
+	sampleRate  beep.SampleRate
+}
+
+// NewBeepPlayer returns a Player backed by faiface/beep.
+func NewBeepPlayer() *BeepPlayer {
+	return &BeepPlayer{}
+}
+
+func (p *BeepPlayer) Play(ctx context.Context, file string, volume float64) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("error opening audio file: %v", err)
+	}
+	defer f.Close()
+
+	streamer, format, err := mp3.Decode(f)
+	if err != nil {
+		return fmt.Errorf("error decoding MP3: %v", err)
+	}
+	defer streamer.Close()
+
+	if !p.initialized || p.sampleRate != format.SampleRate {
+		if err := speaker.Init(format.SampleRate, format.SampleRate.N(time.Second/10)); err != nil {
+			return fmt.Errorf("error initializing speaker: %v", err)
+		}
+		p.initialized = true
+		p.sampleRate = format.SampleRate
+	}
+
+	// Calculate volume adjustment.
+	// Convert percentage to logarithmic scale where:
+	// 0% = silence (very low volume, -4 is approximately -96dB)
+	// 100% = normal volume (0 dB, no change)
+	volumeAdjusted := &effects.Volume{
+		Streamer: streamer,
+		Base:     2,
+		Volume:   -4 + (volume / 100.0 * 4), // Scale from -4 to 0
+	}
+
+	done := make(chan struct{})
+	speaker.Play(beep.Seq(volumeAdjusted, beep.Callback(func() {
+		close(done)
+	})))
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		speaker.Clear()
+		return ctx.Err()
+	}
+}
+
+func (p *BeepPlayer) Stop() error {
+	speaker.Clear()
+	return nil
+}
+
+func (p *BeepPlayer) Test() error {
+	// speaker.Init only succeeds once the output device can actually be
+	// opened, so a trial Play of a short clip is the real test; here we
+	// just confirm the backend has nothing else to validate up front.
+	return nil
+}
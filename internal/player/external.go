@@ -0,0 +1,217 @@
+package player
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ExternalPlayer plays audio by shelling out to mpv or mplayer. mpv is kept
+// running as a single long-lived process (driven over its JSON IPC socket)
+// so that individual plays don't restart the audio device; mplayer has no
+// such IPC mode and is run once per play instead.
+type ExternalPlayer struct {
+	binary string // "mpv" or "mplayer", resolved at construction time
+
+	mu         sync.Mutex
+	cmd        *exec.Cmd // mpv: the long-lived process; mplayer: the in-flight play
+	socketPath string    // mpv only
+
+	// playMu serializes Play, like BeepPlayer.mu does, so two overlapping
+	// plays never share mpv's single IPC connection/event stream at once.
+	playMu sync.Mutex
+}
+
+// NewExternalPlayer resolves the preferred binary ("mpv" or "mplayer") on
+// PATH, falling back from mpv to mplayer if mpv isn't installed.
+func NewExternalPlayer(preferred string) (*ExternalPlayer, error) {
+	bin := preferred
+	if _, err := exec.LookPath(bin); err != nil {
+		if preferred != "mpv" {
+			return nil, fmt.Errorf("audio backend %q not found in PATH: %w", preferred, err)
+		}
+		if _, err2 := exec.LookPath("mplayer"); err2 != nil {
+			return nil, fmt.Errorf("neither mpv nor mplayer found in PATH: %w", err)
+		}
+		bin = "mplayer"
+	}
+	return &ExternalPlayer{binary: bin}, nil
+}
+
+func (p *ExternalPlayer) Play(ctx context.Context, file string, volume float64) error {
+	p.playMu.Lock()
+	defer p.playMu.Unlock()
+
+	if p.binary == "mpv" {
+		return p.playMPV(ctx, file, volume)
+	}
+	return p.playMPlayer(ctx, file, volume)
+}
+
+func (p *ExternalPlayer) playMPlayer(ctx context.Context, file string, volume float64) error {
+	cmd := exec.CommandContext(ctx, "mplayer",
+		"-volume", fmt.Sprintf("%.0f", volume),
+		"-really-quiet",
+		file,
+	)
+
+	p.mu.Lock()
+	p.cmd = cmd
+	p.mu.Unlock()
+
+	err := cmd.Run()
+
+	p.mu.Lock()
+	p.cmd = nil
+	p.mu.Unlock()
+
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+func (p *ExternalPlayer) playMPV(ctx context.Context, file string, volume float64) error {
+	if err := p.ensureMPVStarted(); err != nil {
+		return err
+	}
+
+	conn, err := p.dialIPC()
+	if err != nil {
+		return fmt.Errorf("connecting to mpv ipc socket: %w", err)
+	}
+	defer conn.Close()
+
+	if err := sendIPCCommand(conn, "set_property", "volume", volume); err != nil {
+		return fmt.Errorf("setting mpv volume: %w", err)
+	}
+	if err := sendIPCCommand(conn, "loadfile", file, "replace"); err != nil {
+		return fmt.Errorf("loading file in mpv: %w", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			var event struct {
+				Event string `json:"event"`
+			}
+			if err := json.Unmarshal(scanner.Bytes(), &event); err == nil && event.Event == "end-file" {
+				done <- nil
+				return
+			}
+		}
+		done <- scanner.Err()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		_ = sendIPCCommand(conn, "stop")
+		return ctx.Err()
+	}
+}
+
+// ensureMPVStarted starts the long-lived mpv process (with its IPC socket)
+// the first time it's needed, and leaves it running for subsequent plays.
+func (p *ExternalPlayer) ensureMPVStarted() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cmd != nil && p.cmd.ProcessState == nil {
+		return nil
+	}
+
+	socketPath := filepath.Join(os.TempDir(), fmt.Sprintf("ezan-mpv-%d.sock", os.Getpid()))
+	os.Remove(socketPath) // stale socket from a previous crashed run
+
+	cmd := exec.Command("mpv",
+		"--idle",
+		"--no-video",
+		"--really-quiet",
+		"--input-ipc-server="+socketPath,
+	)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("starting mpv: %w", err)
+	}
+
+	p.cmd = cmd
+	p.socketPath = socketPath
+	return nil
+}
+
+func (p *ExternalPlayer) dialIPC() (net.Conn, error) {
+	var lastErr error
+	for i := 0; i < 40; i++ {
+		conn, err := net.Dial("unix", p.socketPath)
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+		time.Sleep(50 * time.Millisecond)
+	}
+	return nil, lastErr
+}
+
+func sendIPCCommand(conn net.Conn, command ...interface{}) error {
+	payload, err := json.Marshal(map[string]interface{}{"command": command})
+	if err != nil {
+		return err
+	}
+	payload = append(payload, '\n')
+	_, err = conn.Write(payload)
+	return err
+}
+
+// Stop terminates the backend's child process(es). For mpv this tears down
+// the long-lived instance (the next Play starts a fresh one); for mplayer it
+// interrupts whichever one-shot play is currently running.
+func (p *ExternalPlayer) Stop() error {
+	p.mu.Lock()
+	cmd := p.cmd
+	p.cmd = nil
+	socketPath := p.socketPath
+	p.socketPath = ""
+	p.mu.Unlock()
+
+	if cmd == nil || cmd.Process == nil {
+		return nil
+	}
+
+	if socketPath != "" {
+		defer os.Remove(socketPath)
+	}
+
+	if err := cmd.Process.Signal(syscall.SIGTERM); err != nil {
+		return fmt.Errorf("signaling %s: %w", p.binary, err)
+	}
+
+	waitDone := make(chan error, 1)
+	go func() { waitDone <- cmd.Wait() }()
+
+	select {
+	case <-waitDone:
+		return nil
+	case <-time.After(3 * time.Second):
+		_ = cmd.Process.Kill()
+		<-waitDone
+		return nil
+	}
+}
+
+func (p *ExternalPlayer) Test() error {
+	if p.binary == "mplayer" {
+		// Resolved on PATH at construction time; nothing more to verify.
+		return nil
+	}
+	return p.ensureMPVStarted()
+}
@@ -0,0 +1,35 @@
+// Package player provides pluggable audio backends for playing adhan and
+// other notification clips.
+package player
+
+import (
+	"context"
+	"fmt"
+)
+
+// Player plays audio clips through a configured backend. Implementations
+// must be safe to reuse across repeated Play calls.
+type Player interface {
+	// Play plays the file at the given volume (0-100) and blocks until
+	// playback finishes or ctx is cancelled.
+	Play(ctx context.Context, file string, volume float64) error
+	// Stop interrupts any playback in progress and releases the backend's
+	// resources (child processes, open devices). It is safe to call even
+	// when nothing is playing, e.g. during shutdown.
+	Stop() error
+	// Test verifies that the backend is reachable and able to play audio.
+	Test() error
+}
+
+// New builds the Player for the given backend name. An empty name defaults
+// to "beep" for backwards compatibility with existing config files.
+func New(backend string) (Player, error) {
+	switch backend {
+	case "", "beep":
+		return NewBeepPlayer(), nil
+	case "mpv", "mplayer":
+		return NewExternalPlayer(backend)
+	default:
+		return nil, fmt.Errorf("unknown audio_backend %q", backend)
+	}
+}
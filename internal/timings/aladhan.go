@@ -0,0 +1,183 @@
+package timings
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"ezan/internal/logging"
+)
+
+// DefaultAladhanBaseURL is used when no override is configured.
+const DefaultAladhanBaseURL = "https://api.aladhan.com"
+
+// AladhanProvider fetches prayer times from the aladhan.com /v1/timings
+// endpoint, caching the response to disk so the midnight refresh can still
+// work offline, and falling back to a local provider when both the network
+// and the cache are unavailable.
+type AladhanProvider struct {
+	BaseURL  string
+	Method   int // aladhan.com numeric calculation method id
+	School   int // 0 = Shafi, 1 = Hanafi
+	CacheDir string
+	Fallback Provider
+
+	HTTPClient *http.Client
+}
+
+// NewAladhanProvider returns a Provider backed by aladhan.com. baseURL and
+// cacheDir fall back to DefaultAladhanBaseURL and "cache" when empty.
+func NewAladhanProvider(baseURL string, method, school int, cacheDir string, fallback Provider) *AladhanProvider {
+	if baseURL == "" {
+		baseURL = DefaultAladhanBaseURL
+	}
+	if cacheDir == "" {
+		cacheDir = "cache"
+	}
+	return &AladhanProvider{
+		BaseURL:  baseURL,
+		Method:   method,
+		School:   school,
+		CacheDir: cacheDir,
+		Fallback: fallback,
+	}
+}
+
+func (p *AladhanProvider) Today(date time.Time, coords Coordinates) (Times, error) {
+	logger := logging.For(logging.Timings)
+	cachePath := p.cachePath(date)
+
+	times, err := p.fetch(date, coords)
+	if err == nil {
+		if cacheErr := p.writeCache(cachePath, times); cacheErr != nil {
+			logger.Warn("failed to cache aladhan timings", "error", cacheErr)
+		}
+		return times, nil
+	}
+	logger.Warn("aladhan.com request failed, trying cache", "error", err)
+
+	if cached, cacheErr := p.readCache(cachePath); cacheErr == nil {
+		return cached, nil
+	}
+
+	if p.Fallback == nil {
+		return Times{}, fmt.Errorf("aladhan unavailable and no cached or fallback timings for %s: %w", date.Format("2006-01-02"), err)
+	}
+	logger.Warn("no cached timings either, falling back to local calculation", "date", date.Format("2006-01-02"))
+	return p.Fallback.Today(date, coords)
+}
+
+func (p *AladhanProvider) httpClient() *http.Client {
+	if p.HTTPClient != nil {
+		return p.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+type aladhanResponse struct {
+	Data struct {
+		Timings struct {
+			Fajr    string `json:"Fajr"`
+			Sunrise string `json:"Sunrise"`
+			Dhuhr   string `json:"Dhuhr"`
+			Asr     string `json:"Asr"`
+			Maghrib string `json:"Maghrib"`
+			Isha    string `json:"Isha"`
+		} `json:"timings"`
+	} `json:"data"`
+}
+
+func (p *AladhanProvider) fetch(date time.Time, coords Coordinates) (Times, error) {
+	endpoint := fmt.Sprintf("%s/v1/timings/%s", strings.TrimRight(p.BaseURL, "/"), date.Format("02-01-2006"))
+
+	q := url.Values{}
+	q.Set("latitude", strconv.FormatFloat(coords.Latitude, 'f', -1, 64))
+	q.Set("longitude", strconv.FormatFloat(coords.Longitude, 'f', -1, 64))
+	q.Set("method", strconv.Itoa(p.Method))
+	q.Set("school", strconv.Itoa(p.School))
+
+	req, err := http.NewRequest(http.MethodGet, endpoint+"?"+q.Encode(), nil)
+	if err != nil {
+		return Times{}, err
+	}
+
+	resp, err := p.httpClient().Do(req)
+	if err != nil {
+		return Times{}, fmt.Errorf("requesting aladhan timings: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Times{}, fmt.Errorf("aladhan returned status %d", resp.StatusCode)
+	}
+
+	var parsed aladhanResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return Times{}, fmt.Errorf("decoding aladhan response: %w", err)
+	}
+
+	fields := parsed.Data.Timings
+	var times Times
+	for _, f := range []struct {
+		dst   *time.Time
+		value string
+	}{
+		{&times.Fajr, fields.Fajr},
+		{&times.Sunrise, fields.Sunrise},
+		{&times.Dhuhr, fields.Dhuhr},
+		{&times.Asr, fields.Asr},
+		{&times.Maghrib, fields.Maghrib},
+		{&times.Isha, fields.Isha},
+	} {
+		t, err := parseClock(f.value, date)
+		if err != nil {
+			return Times{}, fmt.Errorf("parsing aladhan timing %q: %w", f.value, err)
+		}
+		*f.dst = t
+	}
+	return times, nil
+}
+
+// parseClock parses an aladhan timing value, e.g. "05:32 (+03)", attaching
+// the hour and minute to date's year/month/day in date's location.
+func parseClock(value string, date time.Time) (time.Time, error) {
+	hhmm := strings.TrimSpace(strings.SplitN(value, " ", 2)[0])
+	t, err := time.ParseInLocation("15:04", hhmm, date.Location())
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), 0, 0, date.Location()), nil
+}
+
+func (p *AladhanProvider) cachePath(date time.Time) string {
+	return filepath.Join(p.CacheDir, fmt.Sprintf("timings-%s.json", date.Format("2006-01-02")))
+}
+
+func (p *AladhanProvider) writeCache(path string, t Times) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func (p *AladhanProvider) readCache(path string) (Times, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Times{}, err
+	}
+	var t Times
+	if err := json.Unmarshal(data, &t); err != nil {
+		return Times{}, err
+	}
+	return t, nil
+}
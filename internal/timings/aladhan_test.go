@@ -0,0 +1,93 @@
+package timings
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseClock(t *testing.T) {
+	date := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"with timezone offset", "05:32 (+03)", time.Date(2025, 1, 15, 5, 32, 0, 0, time.UTC), false},
+		{"bare hh:mm", "13:07", time.Date(2025, 1, 15, 13, 7, 0, 0, time.UTC), false},
+		{"malformed", "not-a-time", time.Time{}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseClock(tc.value, date)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("parseClock(%q) = nil error, want one", tc.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseClock(%q) unexpected error: %v", tc.value, err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("parseClock(%q) = %v, want %v", tc.value, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAladhanFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"data": {
+				"timings": {
+					"Fajr": "05:32 (+03)",
+					"Sunrise": "07:01 (+03)",
+					"Dhuhr": "12:15 (+03)",
+					"Asr": "14:45 (+03)",
+					"Maghrib": "17:20 (+03)",
+					"Isha": "18:50 (+03)"
+				}
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	p := NewAladhanProvider(server.URL, 3, 0, "", nil)
+	date := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	times, err := p.fetch(date, Coordinates{Latitude: 41.0, Longitude: 29.0})
+	if err != nil {
+		t.Fatalf("fetch returned error: %v", err)
+	}
+
+	want := Times{
+		Fajr:    time.Date(2025, 1, 15, 5, 32, 0, 0, time.UTC),
+		Sunrise: time.Date(2025, 1, 15, 7, 1, 0, 0, time.UTC),
+		Dhuhr:   time.Date(2025, 1, 15, 12, 15, 0, 0, time.UTC),
+		Asr:     time.Date(2025, 1, 15, 14, 45, 0, 0, time.UTC),
+		Maghrib: time.Date(2025, 1, 15, 17, 20, 0, 0, time.UTC),
+		Isha:    time.Date(2025, 1, 15, 18, 50, 0, 0, time.UTC),
+	}
+	if !times.Fajr.Equal(want.Fajr) || !times.Sunrise.Equal(want.Sunrise) || !times.Dhuhr.Equal(want.Dhuhr) ||
+		!times.Asr.Equal(want.Asr) || !times.Maghrib.Equal(want.Maghrib) || !times.Isha.Equal(want.Isha) {
+		t.Errorf("fetch() = %+v, want %+v", times, want)
+	}
+}
+
+func TestAladhanFetchBadStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	p := NewAladhanProvider(server.URL, 3, 0, "", nil)
+	if _, err := p.fetch(time.Now(), Coordinates{}); err == nil {
+		t.Fatal("fetch() with a non-200 response should return an error")
+	}
+}
@@ -0,0 +1,53 @@
+package timings
+
+import (
+	"fmt"
+	"time"
+
+	calc "github.com/furkan000/adhango/pkg/calc"
+	data "github.com/furkan000/adhango/pkg/data"
+	util "github.com/furkan000/adhango/pkg/util"
+)
+
+// LocalProvider computes prayer times locally via astronomical calculation
+// (github.com/furkan000/adhango), the original and still-default provider.
+type LocalProvider struct {
+	Method calc.CalculationMethod
+	Madhab calc.AsrJuristicMethod
+}
+
+// NewLocalProvider returns a Provider using the given calculation method and
+// madhab for shadow-length based Asr calculation.
+func NewLocalProvider(method calc.CalculationMethod, madhab calc.AsrJuristicMethod) *LocalProvider {
+	return &LocalProvider{Method: method, Madhab: madhab}
+}
+
+func (p *LocalProvider) Today(date time.Time, coords Coordinates) (Times, error) {
+	c, err := util.NewCoordinates(coords.Latitude, coords.Longitude)
+	if err != nil {
+		return Times{}, fmt.Errorf("error creating coordinates: %v", err)
+	}
+
+	params := calc.NewCalculationParametersBuilder().
+		SetMadhab(p.Madhab).
+		SetMethod(p.Method).
+		Build()
+
+	prayerTimes, err := calc.NewPrayerTimes(c, data.NewDateComponents(date), params)
+	if err != nil {
+		return Times{}, fmt.Errorf("error calculating prayer times: %v", err)
+	}
+
+	if err := prayerTimes.SetTimeZone(date.Location().String()); err != nil {
+		return Times{}, fmt.Errorf("error setting timezone: %v", err)
+	}
+
+	return Times{
+		Fajr:    prayerTimes.Fajr,
+		Sunrise: prayerTimes.Sunrise,
+		Dhuhr:   prayerTimes.Dhuhr,
+		Asr:     prayerTimes.Asr,
+		Maghrib: prayerTimes.Maghrib,
+		Isha:    prayerTimes.Isha,
+	}, nil
+}
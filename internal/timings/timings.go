@@ -0,0 +1,26 @@
+// Package timings computes the daily prayer times, either from local
+// astronomical calculation or from an online provider.
+package timings
+
+import "time"
+
+// Times holds the computed prayer times for a single day.
+type Times struct {
+	Fajr    time.Time
+	Sunrise time.Time
+	Dhuhr   time.Time
+	Asr     time.Time
+	Maghrib time.Time
+	Isha    time.Time
+}
+
+// Coordinates is the location prayer times are computed for.
+type Coordinates struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Provider computes the prayer times for a given date and location.
+type Provider interface {
+	Today(date time.Time, coords Coordinates) (Times, error)
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateTag(t *testing.T) {
+	date := time.Date(2025, 1, 15, 5, 32, 0, 0, time.UTC)
+	if got, want := dateTag("adhan", date), "adhan-20250115"; got != want {
+		t.Errorf("dateTag(%q, %v) = %q, want %q", "adhan", date, got, want)
+	}
+}
+
+func TestSplitDateTag(t *testing.T) {
+	cases := []struct {
+		name       string
+		tag        string
+		wantPrefix string
+		wantDate   string
+		wantOK     bool
+	}{
+		{"adhan date tag", "adhan-20250115", "adhan", "20250115", true},
+		{"sela date tag", "sela-20251231", "sela", "20251231", true},
+		{"bare prayer name tag", "fajr", "", "", false},
+		{"generic tag without a date part", "adhan", "", "", false},
+		{"date part too short", "adhan-2025", "", "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			prefix, datePart, ok := splitDateTag(tc.tag)
+			if ok != tc.wantOK || prefix != tc.wantPrefix || datePart != tc.wantDate {
+				t.Errorf("splitDateTag(%q) = (%q, %q, %v), want (%q, %q, %v)",
+					tc.tag, prefix, datePart, ok, tc.wantPrefix, tc.wantDate, tc.wantOK)
+			}
+		})
+	}
+}